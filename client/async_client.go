@@ -0,0 +1,413 @@
+// Package client provides an outbound HTTP client for fire-and-forget work
+// such as webhook delivery: requests are queued per destination host and
+// delivered by a bounded worker pool with retry and backoff, so one slow
+// or failing host cannot starve deliveries to every other host.
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy controls how a failed delivery is retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of delivery attempts, including the
+	// first. Defaults to 5.
+	MaxAttempts int
+
+	// BaseDelay is the backoff delay before the first retry. Defaults to
+	// 200ms.
+	BaseDelay time.Duration
+
+	// CapDelay is the maximum backoff delay regardless of attempt count.
+	// Defaults to 30s.
+	CapDelay time.Duration
+
+	// Factor is the backoff multiplier applied per attempt. Defaults to 2.
+	Factor float64
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	out := p
+	if out.MaxAttempts <= 0 {
+		out.MaxAttempts = 5
+	}
+	if out.BaseDelay <= 0 {
+		out.BaseDelay = 200 * time.Millisecond
+	}
+	if out.CapDelay <= 0 {
+		out.CapDelay = 30 * time.Second
+	}
+	if out.Factor <= 0 {
+		out.Factor = 2
+	}
+	return out
+}
+
+// backoff returns the delay before attempt (1-indexed) with jitter, capped
+// at p.CapDelay.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(p.Factor, float64(attempt-1))
+	if delay > float64(p.CapDelay) {
+		delay = float64(p.CapDelay)
+	}
+	jittered, err := rand.Int(rand.Reader, big.NewInt(int64(delay)+1))
+	if err != nil {
+		return time.Duration(delay)
+	}
+	return time.Duration(jittered.Int64())
+}
+
+// Callback is invoked once a delivery ends with a response or a final
+// error: on success, or after the last failed attempt. It is not invoked
+// for a job dropped by Cancel, CancelByHost, a bad-host short-circuit, or
+// its ctx being done during backoff. resp is nil when err is non-nil.
+type Callback func(resp *http.Response, attempt int, err error)
+
+// EnqueueOptions configures a single queued delivery.
+type EnqueueOptions struct {
+	Retry    RetryPolicy
+	Callback Callback
+}
+
+// Stats is a point-in-time snapshot of AsyncClient counters, suitable for
+// exposing on a Prometheus-style metrics endpoint.
+type Stats struct {
+	Enqueued   uint64
+	Delivered  uint64
+	Retried    uint64
+	Dropped    uint64
+	QueueDepth uint64
+}
+
+// job is a single queued delivery.
+type job struct {
+	id       string
+	ctx      context.Context
+	req      *http.Request
+	retry    RetryPolicy
+	callback Callback
+	attempt  int
+	canceled atomic.Bool
+}
+
+// hostQueue is the worker pool and queue serving a single destination
+// host, so a slow or down host cannot block deliveries to any other host.
+type hostQueue struct {
+	host     string
+	jobs     chan *job
+	done     chan struct{}
+	failures atomic.Int64
+	badUntil atomic.Int64 // unix nano; 0 means not bad
+}
+
+// Config configures an AsyncClient.
+type Config struct {
+	// HTTPClient performs the actual delivery. http.DefaultClient is used
+	// when nil.
+	HTTPClient *http.Client
+
+	// WorkersPerHost is how many goroutines serve each host's queue.
+	// Defaults to 2.
+	WorkersPerHost int
+
+	// QueueSizePerHost bounds how many pending deliveries a host queue may
+	// hold before Enqueue blocks. Defaults to 256.
+	QueueSizePerHost int
+
+	// DefaultRetry is used for deliveries enqueued without an explicit
+	// RetryPolicy.
+	DefaultRetry RetryPolicy
+
+	// BadHostThreshold is how many consecutive failures mark a host "bad",
+	// short-circuiting further deliveries until BadHostCooldown elapses.
+	// Defaults to 5.
+	BadHostThreshold int
+
+	// BadHostCooldown is how long a bad host is skipped before being
+	// retried. Defaults to 30s.
+	BadHostCooldown time.Duration
+}
+
+func (cfg Config) withDefaults() Config {
+	out := cfg
+	if out.HTTPClient == nil {
+		out.HTTPClient = http.DefaultClient
+	}
+	if out.WorkersPerHost <= 0 {
+		out.WorkersPerHost = 2
+	}
+	if out.QueueSizePerHost <= 0 {
+		out.QueueSizePerHost = 256
+	}
+	if out.BadHostThreshold <= 0 {
+		out.BadHostThreshold = 5
+	}
+	if out.BadHostCooldown <= 0 {
+		out.BadHostCooldown = 30 * time.Second
+	}
+	out.DefaultRetry = out.DefaultRetry.withDefaults()
+	return out
+}
+
+// AsyncClient queues outbound HTTP requests and delivers them from a
+// bounded worker pool sharded by destination host.
+type AsyncClient struct {
+	cfg Config
+
+	mu     sync.Mutex
+	queues map[string]*hostQueue
+	jobs   map[string]*job
+
+	stopped atomic.Bool
+	wg      sync.WaitGroup
+
+	enqueued  atomic.Uint64
+	delivered atomic.Uint64
+	retried   atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// New returns an AsyncClient. Callers should defer client.Stop(ctx) to
+// drain in-flight deliveries on shutdown.
+func New(cfg Config) *AsyncClient {
+	return &AsyncClient{
+		cfg:    cfg.withDefaults(),
+		queues: make(map[string]*hostQueue),
+		jobs:   make(map[string]*job),
+	}
+}
+
+// Enqueue schedules req for asynchronous delivery and returns an id that
+// can later be passed to Cancel. 4xx responses are not retried; 5xx
+// responses and network errors are retried per opts.Retry (or
+// Config.DefaultRetry, if opts.Retry.MaxAttempts is zero).
+func (ac *AsyncClient) Enqueue(ctx context.Context, req *http.Request, opts EnqueueOptions) (string, error) {
+	if ac.stopped.Load() {
+		return "", errors.New("client: AsyncClient is stopped")
+	}
+
+	retry := opts.Retry
+	if retry.MaxAttempts == 0 {
+		retry = ac.cfg.DefaultRetry
+	} else {
+		retry = retry.withDefaults()
+	}
+
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+
+	j := &job{id: id, ctx: ctx, req: req, retry: retry, callback: opts.Callback}
+
+	host := req.URL.Hostname()
+	q := ac.queueFor(host)
+
+	ac.mu.Lock()
+	ac.jobs[id] = j
+	ac.mu.Unlock()
+
+	select {
+	case q.jobs <- j:
+		ac.enqueued.Add(1)
+		return id, nil
+	case <-ctx.Done():
+		ac.mu.Lock()
+		delete(ac.jobs, id)
+		ac.mu.Unlock()
+		return "", ctx.Err()
+	}
+}
+
+// queueFor returns the hostQueue for host, creating and starting its
+// workers on first use.
+func (ac *AsyncClient) queueFor(host string) *hostQueue {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if q, ok := ac.queues[host]; ok {
+		return q
+	}
+
+	q := &hostQueue{
+		host: host,
+		jobs: make(chan *job, ac.cfg.QueueSizePerHost),
+		done: make(chan struct{}),
+	}
+	ac.queues[host] = q
+
+	for i := 0; i < ac.cfg.WorkersPerHost; i++ {
+		ac.wg.Add(1)
+		go ac.worker(q)
+	}
+	return q
+}
+
+// worker drains q.jobs, delivering each job with retry until it succeeds,
+// exhausts its attempts, or is canceled.
+func (ac *AsyncClient) worker(q *hostQueue) {
+	defer ac.wg.Done()
+
+	for {
+		select {
+		case j, ok := <-q.jobs:
+			if !ok {
+				return
+			}
+			ac.deliver(q, j)
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// deliver attempts j, retrying with backoff per j.retry until it succeeds,
+// is abandoned as non-retriable, or exhausts MaxAttempts.
+func (ac *AsyncClient) deliver(q *hostQueue, j *job) {
+	defer func() {
+		ac.mu.Lock()
+		delete(ac.jobs, j.id)
+		ac.mu.Unlock()
+	}()
+
+	for j.attempt < j.retry.MaxAttempts {
+		if j.canceled.Load() {
+			ac.dropped.Add(1)
+			return
+		}
+
+		if badUntil := q.badUntil.Load(); badUntil != 0 && time.Now().UnixNano() < badUntil {
+			ac.dropped.Add(1)
+			return
+		}
+
+		j.attempt++
+		resp, err := ac.cfg.HTTPClient.Do(j.req)
+
+		retriable := err != nil || (resp != nil && resp.StatusCode >= 500)
+		if err == nil && resp != nil && resp.StatusCode < 500 {
+			q.failures.Store(0)
+			q.badUntil.Store(0)
+			ac.delivered.Add(1)
+			if j.callback != nil {
+				j.callback(resp, j.attempt, nil)
+			}
+			return
+		}
+
+		if retriable {
+			failures := q.failures.Add(1)
+			if int(failures) >= ac.cfg.BadHostThreshold {
+				q.badUntil.Store(time.Now().Add(ac.cfg.BadHostCooldown).UnixNano())
+			}
+		}
+
+		if !retriable || j.attempt >= j.retry.MaxAttempts {
+			ac.dropped.Add(1)
+			if j.callback != nil {
+				j.callback(resp, j.attempt, err)
+			}
+			return
+		}
+
+		ac.retried.Add(1)
+		select {
+		case <-time.After(j.retry.backoff(j.attempt)):
+		case <-j.ctx.Done():
+			ac.dropped.Add(1)
+			return
+		}
+	}
+}
+
+// Cancel marks a pending job so that no further delivery attempt is made
+// for it, whether it is still queued or waiting to retry; an attempt
+// already in flight is not interrupted. It returns false if id is unknown
+// or its delivery has already finished.
+func (ac *AsyncClient) Cancel(id string) bool {
+	ac.mu.Lock()
+	j, ok := ac.jobs[id]
+	ac.mu.Unlock()
+	if !ok {
+		return false
+	}
+	j.canceled.Store(true)
+	return true
+}
+
+// CancelByHost cancels every job currently queued for host and returns how
+// many were canceled. It is intended for draining a queue once a host is
+// known to be bad, without waiting out each job's own retry schedule.
+func (ac *AsyncClient) CancelByHost(host string) int {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	n := 0
+	for _, j := range ac.jobs {
+		if j.req.URL.Hostname() == host {
+			j.canceled.Store(true)
+			n++
+		}
+	}
+	return n
+}
+
+// Stats returns a snapshot of delivery counters.
+func (ac *AsyncClient) Stats() Stats {
+	ac.mu.Lock()
+	depth := uint64(len(ac.jobs))
+	ac.mu.Unlock()
+
+	return Stats{
+		Enqueued:   ac.enqueued.Load(),
+		Delivered:  ac.delivered.Load(),
+		Retried:    ac.retried.Load(),
+		Dropped:    ac.dropped.Load(),
+		QueueDepth: depth,
+	}
+}
+
+// Stop signals every worker to finish its in-flight delivery and exit,
+// blocking until they do or ctx is done first.
+func (ac *AsyncClient) Stop(ctx context.Context) error {
+	if !ac.stopped.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	ac.mu.Lock()
+	for _, q := range ac.queues {
+		close(q.done)
+	}
+	ac.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		ac.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newID returns a random 128-bit hex-encoded job id.
+func newID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncClientDeliversRequest(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ac := New(Config{})
+	defer ac.Stop(context.Background())
+
+	done := make(chan struct{})
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, nil)
+	_, err := ac.Enqueue(context.Background(), req, EnqueueOptions{
+		Callback: func(resp *http.Response, attempt int, err error) { close(done) },
+	})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("delivery callback did not fire in time")
+	}
+
+	if hits.Load() != 1 {
+		t.Fatalf("server received %d hits, want 1", hits.Load())
+	}
+
+	stats := ac.Stats()
+	if stats.Delivered != 1 {
+		t.Fatalf("Stats().Delivered = %d, want 1", stats.Delivered)
+	}
+}
+
+func TestAsyncClientRetriesOn5xxThenSucceeds(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ac := New(Config{DefaultRetry: RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, CapDelay: 10 * time.Millisecond}})
+	defer ac.Stop(context.Background())
+
+	done := make(chan error, 1)
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, nil)
+	_, err := ac.Enqueue(context.Background(), req, EnqueueOptions{
+		Callback: func(resp *http.Response, attempt int, err error) { done <- err },
+	})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("final delivery error = %v, want nil", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("delivery callback did not fire in time")
+	}
+
+	if hits.Load() != 2 {
+		t.Fatalf("server received %d hits, want 2 (one failure, one retry)", hits.Load())
+	}
+	if ac.Stats().Retried != 1 {
+		t.Fatalf("Stats().Retried = %d, want 1", ac.Stats().Retried)
+	}
+}
+
+func TestAsyncClientCancelPreventsDelivery(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ac := New(Config{WorkersPerHost: 1, DefaultRetry: RetryPolicy{MaxAttempts: 1}})
+	defer ac.Stop(context.Background())
+
+	req1, _ := http.NewRequest(http.MethodPost, srv.URL, nil)
+	req2, _ := http.NewRequest(http.MethodPost, srv.URL, nil)
+
+	// Occupy the single worker so the second job stays queued behind it.
+	if _, err := ac.Enqueue(context.Background(), req1, EnqueueOptions{}); err != nil {
+		t.Fatalf("Enqueue req1: %v", err)
+	}
+	id2, err := ac.Enqueue(context.Background(), req2, EnqueueOptions{})
+	if err != nil {
+		t.Fatalf("Enqueue req2: %v", err)
+	}
+
+	if !ac.Cancel(id2) {
+		t.Fatal("Cancel returned false for a still-queued job")
+	}
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && ac.Stats().QueueDepth > 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if hits.Load() != 1 {
+		t.Fatalf("server received %d hits, want 1 (canceled job must not be delivered)", hits.Load())
+	}
+}
+
+func TestAsyncClientCancelByHost(t *testing.T) {
+	ac := New(Config{WorkersPerHost: 0, QueueSizePerHost: 8})
+	defer ac.Stop(context.Background())
+
+	// Use an address nothing is listening on; workers will be busy
+	// backing off rather than delivering, keeping jobs queued long enough
+	// to cancel.
+	req1, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:1/a", nil)
+	req2, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:1/b", nil)
+
+	if _, err := ac.Enqueue(context.Background(), req1, EnqueueOptions{}); err != nil {
+		t.Fatalf("Enqueue req1: %v", err)
+	}
+	if _, err := ac.Enqueue(context.Background(), req2, EnqueueOptions{}); err != nil {
+		t.Fatalf("Enqueue req2: %v", err)
+	}
+
+	n := ac.CancelByHost("127.0.0.1")
+	if n == 0 {
+		t.Fatal("CancelByHost canceled 0 jobs, want at least 1")
+	}
+}
@@ -0,0 +1,195 @@
+package quick
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Group scopes a set of routes to a specific Host pattern, as returned by
+// Quick.Host. Routes registered through a Group behave exactly like routes
+// registered directly on Quick, except they are only matched when the
+// incoming request's Host header satisfies the Group's pattern.
+type Group struct {
+	quick   *Quick
+	pattern string
+	label   string
+}
+
+// hostRoute pairs a compiled host matcher with the routes registered under
+// it, so the router can dispatch on Host before falling back to the default
+// tree.
+type hostRoute struct {
+	pattern string
+	label   string
+	tree    *Quick
+}
+
+// hostParamContextKeyType is unexported so no other package can construct
+// a colliding context key.
+type hostParamContextKeyType struct{}
+
+var hostParamContextKey = hostParamContextKeyType{}
+
+// Host returns a Group scoped to pattern, which may be an exact host
+// ("api.example.com"), a wildcard subdomain ("*.example.com") or a named
+// label (":tenant.example.com") whose matched segment is available via
+// HostParam(c). Each distinct pattern gets its own route tree; a request's
+// Host is checked against the patterns in registration order and the first
+// match wins.
+//
+// The first call to Host installs the dispatch middleware (via q.Use)
+// that checks incoming requests against every registered host pattern
+// before falling through to q's own routes.
+func (q *Quick) Host(pattern string) *Group {
+	if len(q.hostRoutes) == 0 {
+		q.Use(hostDispatchMiddleware(q))
+	}
+
+	for _, hr := range q.hostRoutes {
+		if hr.pattern == pattern {
+			return &Group{quick: hr.tree, pattern: pattern, label: hr.label}
+		}
+	}
+
+	tree := New()
+	label := hostLabel(pattern)
+	q.hostRoutes = append(q.hostRoutes, &hostRoute{pattern: pattern, label: label, tree: tree})
+	return &Group{quick: tree, pattern: pattern, label: label}
+}
+
+// hostLabel extracts the ":name" placeholder from a host pattern, if any,
+// e.g. ":tenant.example.com" -> "tenant". Patterns without a placeholder
+// return an empty label.
+func hostLabel(pattern string) string {
+	first := strings.SplitN(pattern, ".", 2)[0]
+	if strings.HasPrefix(first, ":") {
+		return first[1:]
+	}
+	return ""
+}
+
+// matchHost reports whether host satisfies pattern, returning the value
+// captured by a ":label" or "*" wildcard segment, when present.
+func matchHost(pattern, host string) (captured string, ok bool) {
+	host = strings.ToLower(strings.SplitN(host, ":", 2)[0])
+	pattern = strings.ToLower(pattern)
+
+	switch {
+	case strings.HasPrefix(pattern, "*."):
+		suffix := pattern[1:] // keep leading dot, e.g. ".example.com"
+		if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+			return host[:len(host)-len(suffix)], true
+		}
+		return "", false
+	case strings.HasPrefix(pattern, ":"):
+		rest := pattern[strings.Index(pattern, "."):] // ".example.com"
+		if strings.HasSuffix(host, rest) && len(host) > len(rest) {
+			return host[:len(host)-len(rest)], true
+		}
+		return "", false
+	default:
+		return "", pattern == host
+	}
+}
+
+// resolveHost returns the host-scoped route tree matching host, along with
+// any value captured from a wildcard/labeled pattern segment. It returns
+// (nil, "", false) when no Host group matches, in which case the caller
+// should fall back to the default route tree.
+func (q *Quick) resolveHost(host string) (tree *Quick, captured string, ok bool) {
+	for _, hr := range q.hostRoutes {
+		if val, matched := matchHost(hr.pattern, host); matched {
+			return hr.tree, val, true
+		}
+	}
+	return nil, "", false
+}
+
+// hostDispatchMiddleware routes a request to the matching Host group's
+// tree instead of q's own routes, when one of q.hostRoutes matches
+// r.Host. It is installed automatically the first time Quick.Host is
+// called.
+func hostDispatchMiddleware(q *Quick) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tree, captured, ok := q.resolveHost(r.Host)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if captured != "" {
+				r = r.WithContext(context.WithValue(r.Context(), hostParamContextKey, captured))
+			}
+			tree.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HostParam returns the value captured from a ":label" or "*" segment of
+// the Host pattern that routed c's request, or "" if it was not routed
+// through a Quick.Host group with such a segment. Routes registered
+// through a labeled Group (e.g. Host(":tenant.example.com")) also get this
+// value in c.Params under the label name ("tenant"); HostParam is the
+// lower-level accessor the wildcard ("*") form falls back to, since it has
+// no label name to key Params by.
+func HostParam(c *Ctx) string {
+	val, _ := c.Request.Context().Value(hostParamContextKey).(string)
+	return val
+}
+
+// hostParamHandler wraps next so that, once Ctx exists, the value captured
+// from the Host pattern's ":label" segment is copied into c.Params[label]
+// before next runs. It is a no-op wrapper for Groups without a label.
+func hostParamHandler(label string, next Handler) Handler {
+	if label == "" {
+		return next
+	}
+	return func(c *Ctx) error {
+		if v := HostParam(c); v != "" {
+			if c.Params == nil {
+				c.Params = make(map[string]string)
+			}
+			c.Params[label] = v
+		}
+		return next(c)
+	}
+}
+
+// Use registers middleware that applies only to routes defined on this
+// Group, mirroring Quick.Use.
+func (g *Group) Use(mw ...func(http.Handler) http.Handler) *Group {
+	g.quick.Use(mw...)
+	return g
+}
+
+// Get registers a GET route on this Group's host-scoped tree.
+func (g *Group) Get(pattern string, handler Handler) *Route {
+	return g.quick.Get(pattern, hostParamHandler(g.label, handler))
+}
+
+// Post registers a POST route on this Group's host-scoped tree.
+func (g *Group) Post(pattern string, handler Handler) *Route {
+	return g.quick.Post(pattern, hostParamHandler(g.label, handler))
+}
+
+// Put registers a PUT route on this Group's host-scoped tree.
+func (g *Group) Put(pattern string, handler Handler) *Route {
+	return g.quick.Put(pattern, hostParamHandler(g.label, handler))
+}
+
+// Patch registers a PATCH route on this Group's host-scoped tree.
+func (g *Group) Patch(pattern string, handler Handler) *Route {
+	return g.quick.Patch(pattern, hostParamHandler(g.label, handler))
+}
+
+// Delete registers a DELETE route on this Group's host-scoped tree.
+func (g *Group) Delete(pattern string, handler Handler) *Route {
+	return g.quick.Delete(pattern, hostParamHandler(g.label, handler))
+}
+
+// Options registers an OPTIONS route on this Group's host-scoped tree.
+func (g *Group) Options(pattern string, handler Handler) *Route {
+	return g.quick.Options(pattern, hostParamHandler(g.label, handler))
+}
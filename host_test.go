@@ -0,0 +1,86 @@
+package quick
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMatchHostExact(t *testing.T) {
+	if _, ok := matchHost("api.example.com", "api.example.com"); !ok {
+		t.Fatal("exact host pattern did not match")
+	}
+	if _, ok := matchHost("api.example.com", "other.example.com"); ok {
+		t.Fatal("exact host pattern matched the wrong host")
+	}
+}
+
+func TestMatchHostWildcard(t *testing.T) {
+	captured, ok := matchHost("*.example.com", "acme.example.com")
+	if !ok || captured != "acme" {
+		t.Fatalf("matchHost(*.example.com) = %q, %v, want \"acme\", true", captured, ok)
+	}
+	if _, ok := matchHost("*.example.com", "example.com"); ok {
+		t.Fatal("wildcard pattern matched a host with no subdomain")
+	}
+}
+
+func TestMatchHostLabeled(t *testing.T) {
+	captured, ok := matchHost(":tenant.example.com", "acme.example.com:8080")
+	if !ok || captured != "acme" {
+		t.Fatalf("matchHost(:tenant...) = %q, %v, want \"acme\", true", captured, ok)
+	}
+}
+
+func TestHostLabel(t *testing.T) {
+	cases := map[string]string{
+		":tenant.example.com": "tenant",
+		"*.example.com":       "",
+		"api.example.com":     "",
+	}
+	for pattern, want := range cases {
+		if got := hostLabel(pattern); got != want {
+			t.Errorf("hostLabel(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestHostParamHandlerPopulatesParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), hostParamContextKey, "acme"))
+
+	var gotTenant string
+	handler := hostParamHandler("tenant", func(c *Ctx) error {
+		gotTenant = c.Params["tenant"]
+		return nil
+	})
+
+	c := &Ctx{Request: req, Response: httptest.NewRecorder(), Params: map[string]string{}}
+	if err := handler(c); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if gotTenant != "acme" {
+		t.Fatalf("c.Params[\"tenant\"] = %q, want %q", gotTenant, "acme")
+	}
+}
+
+func TestHostParamHandlerNoLabelIsNoop(t *testing.T) {
+	called := false
+	handler := hostParamHandler("", func(c *Ctx) error {
+		called = true
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	c := &Ctx{Request: req, Response: httptest.NewRecorder()}
+	if err := handler(c); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if !called {
+		t.Fatal("unlabeled handler was never invoked")
+	}
+	if c.Params != nil {
+		t.Fatalf("c.Params = %v, want nil (no label to populate)", c.Params)
+	}
+}
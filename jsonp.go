@@ -0,0 +1,55 @@
+package quick
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// validJSONPCallback matches a safe JS identifier path, e.g. "cb",
+// "angular.callbacks._0". Anything else is rejected rather than written
+// into the response verbatim, since the callback name is otherwise
+// attacker-controlled script text, not just a function to invoke.
+var validJSONPCallback = regexp.MustCompile(`^[\w$]+(\.[\w$]+)*$`)
+
+// jsonpEscaper neutralizes U+2028 (LINE SEPARATOR) and U+2029 (PARAGRAPH
+// SEPARATOR). Both are legal inside a JSON string but are treated as line
+// terminators by JavaScript, so left unescaped they can break a JSONP
+// payload out of its wrapping callback expression.
+var jsonpEscaper = strings.NewReplacer(
+	" ", "\\u2028",
+	" ", "\\u2029",
+)
+
+// JSONP marshals v and writes it wrapped in a JavaScript callback, e.g.
+// handleUsers({"id":1}). The callback name is taken from the "callback"
+// query parameter unless an override is passed in callback.
+func (c *Ctx) JSONP(status int, v any, callback ...string) error {
+	name := c.Query["callback"]
+	if len(callback) > 0 && callback[0] != "" {
+		name = callback[0]
+	}
+	if name == "" {
+		name = "callback"
+	}
+	if !validJSONPCallback.MatchString(name) {
+		return fmt.Errorf("quick: invalid JSONP callback name %q", name)
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(name)
+	buf.WriteByte('(')
+	buf.WriteString(jsonpEscaper.Replace(string(body)))
+	buf.WriteString(");")
+
+	c.Set("Content-Type", "application/javascript; charset=utf-8")
+	c.Set("X-Content-Type-Options", "nosniff")
+	return c.Status(status).Send(buf.Bytes())
+}
@@ -0,0 +1,58 @@
+package quick
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newJSONPCtx(t *testing.T, query map[string]string) (*Ctx, *httptest.ResponseRecorder) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	return &Ctx{Request: req, Response: rec, Query: query}, rec
+}
+
+func TestJSONPWritesCallbackWrappedBody(t *testing.T) {
+	c, rec := newJSONPCtx(t, map[string]string{"callback": "handleUsers"})
+	if err := c.JSONP(http.StatusOK, map[string]int{"id": 1}); err != nil {
+		t.Fatalf("JSONP: %v", err)
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "handleUsers(") || !strings.HasSuffix(body, ");") {
+		t.Fatalf("body = %q, want it wrapped in handleUsers(...);", body)
+	}
+}
+
+func TestJSONPDefaultsCallbackNameWhenMissing(t *testing.T) {
+	c, rec := newJSONPCtx(t, map[string]string{})
+	if err := c.JSONP(http.StatusOK, map[string]int{"id": 1}); err != nil {
+		t.Fatalf("JSONP: %v", err)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "callback(") {
+		t.Fatalf("body = %q, want default \"callback\" name", rec.Body.String())
+	}
+}
+
+func TestJSONPRejectsUnsafeCallbackName(t *testing.T) {
+	c, _ := newJSONPCtx(t, map[string]string{"callback": "alert(document.cookie)//"})
+	err := c.JSONP(http.StatusOK, map[string]int{"id": 1})
+	if err == nil {
+		t.Fatal("JSONP accepted an unsafe callback name")
+	}
+}
+
+func TestJSONPEscapesLineSeparators(t *testing.T) {
+	c, rec := newJSONPCtx(t, map[string]string{"callback": "cb"})
+	if err := c.JSONP(http.StatusOK, map[string]string{"s": "line sep"}); err != nil {
+		t.Fatalf("JSONP: %v", err)
+	}
+	body := rec.Body.String()
+	if strings.ContainsRune(body, ' ') {
+		t.Fatalf("body = %q, want U+2028 escaped rather than written raw", body)
+	}
+	if !strings.Contains(body, `\u2028`) {
+		t.Fatalf("body = %q, want the literal escape sequence", body)
+	}
+}
@@ -0,0 +1,299 @@
+// Package accesslog provides structured request logging for Quick via
+// log/slog, with sampling and redaction suitable for production traffic.
+package accesslog
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// redacted replaces a sensitive header/query/cookie value in the log
+// record, without touching what is actually sent to the client.
+const redacted = "***"
+
+// Config configures the middleware returned by New.
+type Config struct {
+	// Logger receives one record per request. slog.Default() is used when
+	// nil.
+	Logger *slog.Logger
+
+	// TrustedProxies lists CIDRs allowed to set X-Forwarded-For/
+	// X-Real-IP. Requests from any other peer have those headers ignored
+	// in favor of the direct connection's RemoteAddr.
+	TrustedProxies []*net.IPNet
+
+	// RedactHeaders and RedactCookies name headers/cookies (matched
+	// case-insensitively for headers) whose values are replaced with
+	// "***" in the "headers"/"cookies" log groups. RedactQuery does the
+	// same for query parameter values in the "query" attr.
+	RedactHeaders []string
+	RedactQuery   []string
+	RedactCookies []string
+
+	// SampleRate is the fraction of requests logged, in (0,1]. Zero or a
+	// negative value selects the default of 1 (log everything). 5xx responses and requests slower than
+	// SlowThreshold are always logged regardless of sampling.
+	SampleRate float64
+
+	// SlowThreshold is the duration above which a request is always
+	// logged, bypassing SampleRate. Defaults to 1s.
+	SlowThreshold time.Duration
+
+	// Fields lets callers attach additional attributes to every record,
+	// e.g. the authenticated user ID.
+	Fields func(r *http.Request) []slog.Attr
+}
+
+func (cfg *Config) withDefaults() Config {
+	out := *cfg
+	if out.Logger == nil {
+		out.Logger = slog.Default()
+	}
+	if out.SampleRate <= 0 {
+		out.SampleRate = 1
+	}
+	if out.SlowThreshold <= 0 {
+		out.SlowThreshold = time.Second
+	}
+	return out
+}
+
+// responseWriter wraps the handler's http.ResponseWriter so the middleware
+// can measure the status code and byte count written by any of Ctx's
+// writers (String/JSON/Send/SSE all ultimately write through it). It
+// forwards http.Flusher and http.Hijacker to the wrapped writer when
+// supported, so installing accesslog ahead of a streaming (SSE) or
+// websocket-upgrading handler doesn't silently break it.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytesOut    int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytesOut += n
+	return n, err
+}
+
+// Flush implements http.Flusher by forwarding to the wrapped writer, when
+// it supports it. This is required for Ctx.SSE to work behind accesslog.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker by forwarding to the wrapped writer,
+// when it supports it.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("accesslog: underlying ResponseWriter does not support Hijack")
+	}
+	return h.Hijack()
+}
+
+// New returns standard net/http middleware, suitable for Quick.Use, that
+// logs one structured record per request via cfg.Logger.
+func New(cfg Config) func(http.Handler) http.Handler {
+	resolved := cfg.withDefaults()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			wrapped := &responseWriter{ResponseWriter: w}
+
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" {
+				requestID = generateRequestID()
+				r.Header.Set("X-Request-ID", requestID)
+			}
+			wrapped.Header().Set("X-Request-ID", requestID)
+
+			bytesIn := r.ContentLength
+
+			next.ServeHTTP(wrapped, r)
+
+			duration := time.Since(start)
+			status := wrapped.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			if !resolved.shouldLog(status, duration) {
+				return
+			}
+
+			attrs := []slog.Attr{
+				slog.Time("ts", start),
+				slog.String("remote_ip", resolved.remoteIP(r)),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("query", resolved.redactedQuery(r)),
+				slog.Int("status", status),
+				slog.Int64("bytes_in", bytesIn),
+				slog.Int("bytes_out", wrapped.bytesOut),
+				slog.Float64("duration_ms", float64(duration.Microseconds())/1000),
+				slog.String("referer", r.Referer()),
+				slog.String("user_agent", r.UserAgent()),
+				slog.String("request_id", requestID),
+				resolved.headerGroup(r),
+				resolved.cookieGroup(r),
+			}
+
+			if resolved.Fields != nil {
+				attrs = append(attrs, resolved.Fields(r)...)
+			}
+
+			resolved.Logger.LogAttrs(r.Context(), slog.LevelInfo, "request", attrs...)
+		})
+	}
+}
+
+// shouldLog decides whether a request is logged: 5xx and slow requests
+// always are; everything else is subject to SampleRate.
+func (cfg Config) shouldLog(status int, duration time.Duration) bool {
+	if status >= 500 || duration >= cfg.SlowThreshold {
+		return true
+	}
+	if cfg.SampleRate >= 1 {
+		return true
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return true
+	}
+	return float64(n.Int64())/1_000_000 < cfg.SampleRate
+}
+
+// remoteIP returns the client's address, honoring X-Forwarded-For/
+// X-Real-IP only when r's peer is in cfg.TrustedProxies.
+func (cfg Config) remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if !cfg.isTrustedProxy(net.ParseIP(host)) {
+		return host
+	}
+
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		parts := strings.Split(xff, ",")
+		return strings.TrimSpace(parts[0])
+	}
+	if xri := r.Header.Get("X-Real-IP"); xri != "" {
+		return xri
+	}
+	return host
+}
+
+func (cfg Config) isTrustedProxy(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range cfg.TrustedProxies {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactedQuery returns r's query string with every RedactQuery key's
+// value replaced by "***".
+func (cfg Config) redactedQuery(r *http.Request) string {
+	if len(cfg.RedactQuery) == 0 {
+		return r.URL.RawQuery
+	}
+
+	values := r.URL.Query()
+	for _, key := range cfg.RedactQuery {
+		if _, ok := values[key]; ok {
+			values.Set(key, redacted)
+		}
+	}
+	return values.Encode()
+}
+
+// headerGroup returns a slog group logging every request header except
+// Cookie, with names in cfg.RedactHeaders replaced by "***".
+func (cfg Config) headerGroup(r *http.Request) slog.Attr {
+	attrs := make([]any, 0, len(r.Header))
+	for name, values := range r.Header {
+		// Cookies are logged one by one by cookieGroup, where RedactCookies
+		// applies.
+		if name == "Cookie" {
+			continue
+		}
+		value := strings.Join(values, ",")
+		if cfg.isRedactedHeader(name) {
+			value = redacted
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.Group("headers", attrs...)
+}
+
+// cookieGroup returns a slog group logging every request cookie, with
+// names in cfg.RedactCookies replaced by "***".
+func (cfg Config) cookieGroup(r *http.Request) slog.Attr {
+	cookies := r.Cookies()
+	attrs := make([]any, 0, len(cookies))
+	for _, c := range cookies {
+		value := c.Value
+		if cfg.isRedactedCookie(c.Name) {
+			value = redacted
+		}
+		attrs = append(attrs, slog.String(c.Name, value))
+	}
+	return slog.Group("cookies", attrs...)
+}
+
+func (cfg Config) isRedactedHeader(name string) bool {
+	for _, h := range cfg.RedactHeaders {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg Config) isRedactedCookie(name string) bool {
+	for _, c := range cfg.RedactCookies {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+func generateRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
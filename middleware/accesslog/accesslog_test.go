@@ -0,0 +1,89 @@
+package accesslog
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, nil))
+}
+
+func TestAccessLogRedactsConfiguredHeadersAndCookies(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(Config{
+		Logger:        newTestLogger(&buf),
+		RedactHeaders: []string{"Authorization"},
+		RedactCookies: []string{"session"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "topsecret"})
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	out := buf.String()
+	if strings.Contains(out, "secret") {
+		t.Fatalf("log record leaked a redacted value: %s", out)
+	}
+	if strings.Contains(out, "topsecret") {
+		t.Fatalf("log record leaked the session cookie value: %s", out)
+	}
+	if !strings.Contains(out, `"theme":"dark"`) {
+		t.Fatalf("log record dropped a non-redacted cookie: %s", out)
+	}
+}
+
+func TestAccessLogAlwaysLogs5xxRegardlessOfSampling(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(Config{
+		Logger:     newTestLogger(&buf),
+		SampleRate: 1e-9, // drops practically every 2xx request
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !strings.Contains(buf.String(), `"status":500`) {
+		t.Fatalf("log output = %s, want a logged record for the 5xx response", buf.String())
+	}
+}
+
+// flushRecorder embeds httptest.ResponseRecorder to additionally report
+// whether Flush was forwarded through the middleware's responseWriter.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed bool
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushed = true
+}
+
+func TestAccessLogForwardsFlush(t *testing.T) {
+	rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+
+	h := New(Config{Logger: newTestLogger(&bytes.Buffer{})})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("wrapped ResponseWriter does not implement http.Flusher")
+		}
+		f.Flush()
+	}))
+
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !rec.flushed {
+		t.Fatal("accesslog's responseWriter did not forward Flush to the underlying ResponseWriter")
+	}
+}
@@ -0,0 +1,287 @@
+// Package csrf provides CSRF protection for Quick using the double-submit
+// cookie pattern, with an optional synchronizer-token mode backed by
+// middleware/session.
+package csrf
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/jeffotoni/quick"
+	"github.com/jeffotoni/quick/middleware/session"
+)
+
+// unsafeMethods are the HTTP methods validated against the CSRF token.
+// GET/HEAD/OPTIONS/TRACE are considered safe and never checked.
+var unsafeMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// csrfContextKeyType is unexported so no other package can construct a
+// colliding context key.
+type csrfContextKeyType struct{}
+
+var csrfContextKey = csrfContextKeyType{}
+
+// csrfSessionKey namespaces the token stored in the session in
+// synchronizer-token mode.
+const csrfSessionKey = "quick:csrf:token"
+
+// Config configures the middleware returned by New.
+type Config struct {
+	// CookieName is the cookie used to carry the token in double-submit
+	// mode. Defaults to "__Host-csrf", which requires Secure and Path "/"
+	// with no Domain set, per the __Host- cookie prefix rules.
+	CookieName string
+
+	// HeaderName is the request header checked for the token. Defaults to
+	// "X-CSRF-Token".
+	HeaderName string
+
+	// FormField is the form field checked for the token when no header is
+	// present. Defaults to "_csrf".
+	FormField string
+
+	// Rotate issues a new token after every successful validation, instead
+	// of reusing the same token for the cookie's lifetime.
+	Rotate bool
+
+	// TrustedOrigins, when non-empty, requires the Origin (or Referer, if
+	// Origin is absent) of unsafe requests to match one of these values.
+	TrustedOrigins []string
+
+	// ExemptPaths is a list of glob patterns (matched with path.Match
+	// against r.URL.Path) that skip CSRF validation entirely, e.g. for
+	// webhook endpoints.
+	ExemptPaths []string
+
+	// Exempt reports whether a request should skip CSRF validation
+	// entirely. It is checked in addition to ExemptPaths, so either one
+	// exempting a request is enough. Nil means nothing is exempt.
+	Exempt func(r *http.Request) bool
+
+	// Session, when set, switches to the synchronizer-token pattern: the
+	// canonical token is stored server-side in the session instead of in a
+	// cookie; no CSRF cookie is set in this mode.
+	Session bool
+
+	// Secure marks the CSRF cookie Secure. Defaults to true; required by
+	// the __Host- prefix when CookieName keeps that prefix.
+	Secure *bool
+
+	// ErrorHandler handles requests that fail CSRF validation. It defaults
+	// to writing a 403 response.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func (cfg *Config) withDefaults() Config {
+	out := *cfg
+	if out.CookieName == "" {
+		out.CookieName = "__Host-csrf"
+	}
+	if out.HeaderName == "" {
+		out.HeaderName = "X-CSRF-Token"
+	}
+	if out.FormField == "" {
+		out.FormField = "_csrf"
+	}
+	if out.Secure == nil {
+		t := true
+		out.Secure = &t
+	}
+	if out.ErrorHandler == nil {
+		out.ErrorHandler = defaultErrorHandler
+	}
+	return out
+}
+
+func defaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error) {
+	http.Error(w, err.Error(), http.StatusForbidden)
+}
+
+// ErrInvalidToken is passed to Config.ErrorHandler when the submitted
+// token is missing or does not match the expected value.
+var ErrInvalidToken = fmt.Errorf("csrf: invalid or missing token")
+
+// ErrUntrustedOrigin is passed to Config.ErrorHandler when Origin/Referer
+// does not match Config.TrustedOrigins.
+var ErrUntrustedOrigin = fmt.Errorf("csrf: untrusted origin")
+
+// isExempt reports whether r should skip CSRF validation entirely, per
+// cfg.ExemptPaths or cfg.Exempt.
+func (cfg Config) isExempt(r *http.Request) bool {
+	for _, pattern := range cfg.ExemptPaths {
+		if ok, err := path.Match(pattern, r.URL.Path); err == nil && ok {
+			return true
+		}
+	}
+	return cfg.Exempt != nil && cfg.Exempt(r)
+}
+
+// New returns standard net/http middleware, suitable for Quick.Use, that
+// enforces CSRF protection on unsafe (POST/PUT/PATCH/DELETE) requests.
+func New(cfg Config) func(http.Handler) http.Handler {
+	resolved := cfg.withDefaults()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if resolved.isExempt(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			expected, err := expectedToken(w, r, resolved)
+			if err != nil {
+				resolved.ErrorHandler(w, r, err)
+				return
+			}
+
+			if unsafeMethods[r.Method] {
+				if len(resolved.TrustedOrigins) > 0 && !originTrusted(r, resolved.TrustedOrigins) {
+					resolved.ErrorHandler(w, r, ErrUntrustedOrigin)
+					return
+				}
+
+				submitted := r.Header.Get(resolved.HeaderName)
+				if submitted == "" {
+					submitted = r.FormValue(resolved.FormField)
+				}
+				if submitted == "" || subtle.ConstantTimeCompare([]byte(submitted), []byte(expected)) != 1 {
+					resolved.ErrorHandler(w, r, ErrInvalidToken)
+					return
+				}
+
+				if resolved.Rotate {
+					expected, err = rotateToken(w, r, resolved)
+					if err != nil {
+						resolved.ErrorHandler(w, r, err)
+						return
+					}
+				}
+			}
+
+			ctx := context.WithValue(r.Context(), csrfContextKey, expected)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// CSRFToken returns the current request's CSRF token, for embedding in
+// forms or returning to JS clients. It returns "" if the middleware is not
+// installed.
+func CSRFToken(c *quick.Ctx) string {
+	token, _ := c.Request.Context().Value(csrfContextKey).(string)
+	return token
+}
+
+// sessionFor returns the *session.Session attached to r by
+// middleware/session, or nil if it is not installed.
+func sessionFor(r *http.Request) *session.Session {
+	sess, _ := r.Context().Value(quick.SessionContextKey).(*session.Session)
+	return sess
+}
+
+// expectedToken returns the canonical token for this request, minting and
+// persisting a new one when none exists yet.
+func expectedToken(w http.ResponseWriter, r *http.Request, cfg Config) (string, error) {
+	if cfg.Session {
+		sess := sessionFor(r)
+		if sess == nil {
+			return "", fmt.Errorf("csrf: Config.Session is true but middleware/session is not installed")
+		}
+		if token, ok := sess.Get(csrfSessionKey).(string); ok && token != "" {
+			return token, nil
+		}
+		token, err := generateToken()
+		if err != nil {
+			return "", err
+		}
+		sess.Set(csrfSessionKey, token)
+		return token, sess.Save()
+	}
+
+	if cookie, err := r.Cookie(cfg.CookieName); err == nil && cookie.Value != "" {
+		return cookie.Value, nil
+	}
+	return rotateToken(w, r, cfg)
+}
+
+// rotateToken mints a fresh token and returns it. In Session mode the
+// token is persisted server-side only, since Session mode is meant as an
+// alternative to the double-submit cookie, not an addition to it;
+// otherwise it is written to the CSRF cookie.
+func rotateToken(w http.ResponseWriter, r *http.Request, cfg Config) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.Session {
+		sess := sessionFor(r)
+		if sess == nil {
+			return "", fmt.Errorf("csrf: Config.Session is true but middleware/session is not installed")
+		}
+		sess.Set(csrfSessionKey, token)
+		if err := sess.Save(); err != nil {
+			return "", err
+		}
+		return token, nil
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     cfg.CookieName,
+		Value:    token,
+		Path:     "/",
+		Secure:   *cfg.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token, nil
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("csrf: generating token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// originTrusted reports whether r's Origin (falling back to Referer)
+// matches one of trusted, comparing scheme and host exactly rather than by
+// prefix so "https://good.example.com" cannot be satisfied by
+// "https://good.example.com.evil.com" or similar lookalikes.
+func originTrusted(r *http.Request, trusted []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		origin = r.Header.Get("Referer")
+	}
+	if origin == "" {
+		return false
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	for _, t := range trusted {
+		trustedURL, err := url.Parse(t)
+		if err != nil {
+			continue
+		}
+		if originURL.Scheme == trustedURL.Scheme && originURL.Host == trustedURL.Host {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package session
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrCookieTooLarge is returned by CookieStore.Save when the encoded
+// session would exceed the 4KB browser cookie limit and no Fallback is
+// configured. Callers configuring session.New should pair CookieStore with
+// a fallback server-side Store in that case (see CookieStore.Fallback).
+var ErrCookieTooLarge = errors.New("session: encoded cookie exceeds 4KB and no fallback store is configured")
+
+const maxCookieBytes = 4096
+
+// CookieStore keeps session data in the cookie itself: signed with HMAC-
+// SHA256 or, when Encrypt is true, sealed with AES-GCM instead (which also
+// authenticates it).
+// Keys[0] is always used to sign/seal new cookies; the remaining keys are
+// tried in order when verifying, so keys can be rotated without
+// invalidating existing sessions.
+type CookieStore struct {
+	// Keys are 32-byte keys used for HMAC signing or (when Encrypt is
+	// true) AES-256-GCM encryption, newest first.
+	Keys [][]byte
+
+	// Encrypt seals the payload with AES-GCM in place of the HMAC
+	// signature. When false,
+	// values are only signed (and therefore still readable, but not
+	// forgeable, by the client).
+	Encrypt bool
+
+	// Fallback persists sessions whose encoded size exceeds 4KB. When nil,
+	// Save returns ErrCookieTooLarge for oversized sessions instead.
+	Fallback Store
+}
+
+type signedPayload struct {
+	Values    map[string]any `json:"v"`
+	ExpiresAt int64          `json:"e"`
+}
+
+// Get implements Store. id is the raw cookie value produced by Save.
+func (cs *CookieStore) Get(ctx context.Context, id string) (map[string]any, bool, error) {
+	payload, err := cs.open(id)
+	if err != nil {
+		if cs.Fallback != nil {
+			return cs.Fallback.Get(ctx, id)
+		}
+		return nil, false, nil
+	}
+	if time.Now().Unix() > payload.ExpiresAt {
+		return nil, false, nil
+	}
+	return payload.Values, true, nil
+}
+
+// Save implements Store. It returns the encoded cookie value to use in
+// place of id when the caller writes the response cookie.
+func (cs *CookieStore) Save(ctx context.Context, id string, values map[string]any, ttl time.Duration) (string, error) {
+	payload := signedPayload{Values: values, ExpiresAt: time.Now().Add(ttl).Unix()}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("session: marshaling cookie payload: %w", err)
+	}
+
+	sealed, err := cs.seal(raw)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) > maxCookieBytes {
+		if cs.Fallback != nil {
+			return cs.Fallback.Save(ctx, id, values, ttl)
+		}
+		return "", ErrCookieTooLarge
+	}
+	return sealed, nil
+}
+
+// Delete implements Store. CookieStore has nothing to clean up server-side
+// unless a Fallback store is configured.
+func (cs *CookieStore) Delete(ctx context.Context, id string) error {
+	if cs.Fallback != nil {
+		return cs.Fallback.Delete(ctx, id)
+	}
+	return nil
+}
+
+func (cs *CookieStore) seal(raw []byte) (string, error) {
+	if len(cs.Keys) == 0 {
+		return "", errors.New("session: CookieStore requires at least one key")
+	}
+	key := cs.Keys[0]
+
+	if cs.Encrypt {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return "", fmt.Errorf("session: building cipher: %w", err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return "", fmt.Errorf("session: building GCM: %w", err)
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return "", fmt.Errorf("session: generating nonce: %w", err)
+		}
+		sealed := gcm.Seal(nonce, nonce, raw, nil)
+		return base64.RawURLEncoding.EncodeToString(sealed), nil
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(raw)
+	sig := mac.Sum(nil)
+	return base64.RawURLEncoding.EncodeToString(raw) + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func (cs *CookieStore) open(value string) (signedPayload, error) {
+	var payload signedPayload
+
+	if cs.Encrypt {
+		for _, key := range cs.Keys {
+			sealed, err := base64.RawURLEncoding.DecodeString(value)
+			if err != nil {
+				return payload, err
+			}
+			block, err := aes.NewCipher(key)
+			if err != nil {
+				continue
+			}
+			gcm, err := cipher.NewGCM(block)
+			if err != nil {
+				continue
+			}
+			if len(sealed) < gcm.NonceSize() {
+				continue
+			}
+			nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+			raw, err := gcm.Open(nil, nonce, ciphertext, nil)
+			if err != nil {
+				continue
+			}
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return payload, err
+			}
+			return payload, nil
+		}
+		return payload, errors.New("session: could not open cookie with any known key")
+	}
+
+	parts := strings.SplitN(value, ".", 2)
+	if len(parts) != 2 {
+		return payload, errors.New("session: malformed cookie value")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return payload, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return payload, err
+	}
+
+	for _, key := range cs.Keys {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(raw)
+		if hmac.Equal(mac.Sum(nil), sig) {
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return payload, err
+			}
+			return payload, nil
+		}
+	}
+	return payload, errors.New("session: signature verification failed for all known keys")
+}
+
@@ -0,0 +1,125 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKey(b byte) []byte {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = b
+	}
+	return key
+}
+
+func TestCookieStoreSignedRoundTrip(t *testing.T) {
+	cs := &CookieStore{Keys: [][]byte{testKey(1)}}
+	ctx := context.Background()
+
+	cookieValue, err := cs.Save(ctx, "unused", map[string]any{"user": "alice"}, time.Minute)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	values, ok, err := cs.Get(ctx, cookieValue)
+	if err != nil || !ok {
+		t.Fatalf("Get = %v, %v, %v", values, ok, err)
+	}
+	if values["user"] != "alice" {
+		t.Fatalf("values[\"user\"] = %v, want alice", values["user"])
+	}
+}
+
+func TestCookieStoreEncryptedRoundTrip(t *testing.T) {
+	cs := &CookieStore{Keys: [][]byte{testKey(2)}, Encrypt: true}
+	ctx := context.Background()
+
+	cookieValue, err := cs.Save(ctx, "unused", map[string]any{"user": "bob"}, time.Minute)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if strings.Contains(cookieValue, "bob") {
+		t.Fatal("encrypted cookie value leaks plaintext")
+	}
+
+	values, ok, err := cs.Get(ctx, cookieValue)
+	if err != nil || !ok {
+		t.Fatalf("Get = %v, %v, %v", values, ok, err)
+	}
+	if values["user"] != "bob" {
+		t.Fatalf("values[\"user\"] = %v, want bob", values["user"])
+	}
+}
+
+func TestCookieStoreRejectsTamperedSignature(t *testing.T) {
+	cs := &CookieStore{Keys: [][]byte{testKey(3)}}
+	ctx := context.Background()
+
+	cookieValue, err := cs.Save(ctx, "unused", map[string]any{"user": "alice"}, time.Minute)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	tampered := cookieValue + "x"
+	if _, ok, _ := cs.Get(ctx, tampered); ok {
+		t.Fatal("Get accepted a tampered signed cookie")
+	}
+}
+
+func TestCookieStoreKeyRotation(t *testing.T) {
+	oldStore := &CookieStore{Keys: [][]byte{testKey(4)}}
+	cookieValue, err := oldStore.Save(context.Background(), "unused", map[string]any{"user": "carol"}, time.Minute)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// Rotated store: newest key first, old key retained for verification.
+	rotated := &CookieStore{Keys: [][]byte{testKey(5), testKey(4)}}
+	values, ok, err := rotated.Get(context.Background(), cookieValue)
+	if err != nil || !ok {
+		t.Fatalf("Get with rotated keys = %v, %v, %v", values, ok, err)
+	}
+	if values["user"] != "carol" {
+		t.Fatalf("values[\"user\"] = %v, want carol", values["user"])
+	}
+}
+
+func TestCookieStoreFallsBackWhenOversized(t *testing.T) {
+	fallback := NewMemoryStore(time.Hour)
+	defer fallback.Close()
+
+	cs := &CookieStore{Keys: [][]byte{testKey(6)}, Fallback: fallback}
+	big := make([]byte, 8192)
+	for i := range big {
+		big[i] = 'a'
+	}
+
+	id, err := cs.Save(context.Background(), "big-session", map[string]any{"blob": string(big)}, time.Minute)
+	if err != nil {
+		t.Fatalf("Save with fallback: %v", err)
+	}
+	if id != "big-session" {
+		t.Fatalf("Save returned id %q, want the fallback store's unchanged id", id)
+	}
+
+	values, ok, err := cs.Get(context.Background(), "big-session")
+	if err != nil || !ok {
+		t.Fatalf("Get via fallback = %v, %v, %v", values, ok, err)
+	}
+}
+
+func TestCookieStoreWithoutFallbackReturnsErrCookieTooLarge(t *testing.T) {
+	cs := &CookieStore{Keys: [][]byte{testKey(7)}}
+	big := make([]byte, 8192)
+	for i := range big {
+		big[i] = 'a'
+	}
+
+	_, err := cs.Save(context.Background(), "unused", map[string]any{"blob": string(big)}, time.Minute)
+	if err != ErrCookieTooLarge {
+		t.Fatalf("Save error = %v, want ErrCookieTooLarge", err)
+	}
+}
@@ -0,0 +1,125 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// entry is a MemoryStore record paired with its absolute expiration time.
+type entry struct {
+	values  map[string]any
+	expires time.Time
+}
+
+// shardCount controls how many independently-locked shards back a
+// MemoryStore, reducing contention under concurrent request load.
+const shardCount = 32
+
+type shard struct {
+	mu   sync.Mutex
+	data map[string]entry
+}
+
+// MemoryStore is an in-process Store suitable for single-instance
+// deployments and tests. It shards its map to limit lock contention and
+// runs a background goroutine that periodically sweeps expired entries.
+type MemoryStore struct {
+	shards [shardCount]*shard
+	stop   chan struct{}
+	closed atomic.Bool
+}
+
+// NewMemoryStore returns a MemoryStore that sweeps expired sessions every
+// sweepInterval (defaulting to one minute when sweepInterval <= 0).
+func NewMemoryStore(sweepInterval ...time.Duration) *MemoryStore {
+	interval := time.Minute
+	if len(sweepInterval) > 0 && sweepInterval[0] > 0 {
+		interval = sweepInterval[0]
+	}
+
+	ms := &MemoryStore{stop: make(chan struct{})}
+	for i := range ms.shards {
+		ms.shards[i] = &shard{data: make(map[string]entry)}
+	}
+
+	go ms.sweepLoop(interval)
+	return ms
+}
+
+func (ms *MemoryStore) shardFor(id string) *shard {
+	var h uint32
+	for i := 0; i < len(id); i++ {
+		h = h*31 + uint32(id[i])
+	}
+	return ms.shards[h%shardCount]
+}
+
+// Get implements Store.
+func (ms *MemoryStore) Get(_ context.Context, id string) (map[string]any, bool, error) {
+	s := ms.shardFor(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.data[id]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false, nil
+	}
+	return cloneValues(e.values), true, nil
+}
+
+// Save implements Store.
+func (ms *MemoryStore) Save(_ context.Context, id string, values map[string]any, ttl time.Duration) (string, error) {
+	s := ms.shardFor(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[id] = entry{values: cloneValues(values), expires: time.Now().Add(ttl)}
+	return id, nil
+}
+
+// Delete implements Store.
+func (ms *MemoryStore) Delete(_ context.Context, id string) error {
+	s := ms.shardFor(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, id)
+	return nil
+}
+
+// Close stops the background sweeper goroutine. It is safe to call Close
+// concurrently and more than once; only the first call closes ms.stop.
+func (ms *MemoryStore) Close() {
+	if ms.closed.CompareAndSwap(false, true) {
+		close(ms.stop)
+	}
+}
+
+func (ms *MemoryStore) sweepLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ms.stop:
+			return
+		case <-ticker.C:
+			ms.sweep()
+		}
+	}
+}
+
+func (ms *MemoryStore) sweep() {
+	now := time.Now()
+	for _, s := range ms.shards {
+		s.mu.Lock()
+		for id, e := range s.data {
+			if now.After(e.expires) {
+				delete(s.data, id)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
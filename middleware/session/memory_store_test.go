@@ -0,0 +1,92 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreSaveGetDelete(t *testing.T) {
+	ms := NewMemoryStore(time.Hour)
+	defer ms.Close()
+
+	ctx := context.Background()
+	id, err := ms.Save(ctx, "abc", map[string]any{"user": "alice"}, time.Minute)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if id != "abc" {
+		t.Fatalf("Save returned id %q, want %q", id, "abc")
+	}
+
+	values, ok, err := ms.Get(ctx, "abc")
+	if err != nil || !ok {
+		t.Fatalf("Get(abc) = %v, %v, %v", values, ok, err)
+	}
+	if values["user"] != "alice" {
+		t.Fatalf("Get(abc)[\"user\"] = %v, want alice", values["user"])
+	}
+
+	if err := ms.Delete(ctx, "abc"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok, _ := ms.Get(ctx, "abc"); ok {
+		t.Fatal("Get(abc) still found entry after Delete")
+	}
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	ms := NewMemoryStore(time.Hour)
+	defer ms.Close()
+
+	ctx := context.Background()
+	if _, err := ms.Save(ctx, "short", map[string]any{}, time.Millisecond); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok, _ := ms.Get(ctx, "short"); ok {
+		t.Fatal("Get(short) returned an expired entry")
+	}
+}
+
+func TestMemoryStoreSweepRemovesExpired(t *testing.T) {
+	ms := NewMemoryStore(5 * time.Millisecond)
+	defer ms.Close()
+
+	ctx := context.Background()
+	if _, err := ms.Save(ctx, "sweep-me", map[string]any{}, time.Millisecond); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		s := ms.shardFor("sweep-me")
+		s.mu.Lock()
+		_, present := s.data["sweep-me"]
+		s.mu.Unlock()
+		if !present {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("sweeper did not remove expired entry in time")
+}
+
+// TestMemoryStoreCloseConcurrent guards against the double-close panic:
+// every goroutine calling Close concurrently must return cleanly.
+func TestMemoryStoreCloseConcurrent(t *testing.T) {
+	ms := NewMemoryStore(time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ms.Close()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,69 @@
+// Package redis provides a session.Store backed by Redis, for deployments
+// that run more than one Quick instance behind a load balancer.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Store persists session data in Redis, namespaced under Prefix (default
+// "quick:session:") so it can share a Redis instance with other data.
+type Store struct {
+	Client *goredis.Client
+	Prefix string
+}
+
+// New returns a Store backed by client. Prefix defaults to
+// "quick:session:" when empty.
+func New(client *goredis.Client, prefix string) *Store {
+	if prefix == "" {
+		prefix = "quick:session:"
+	}
+	return &Store{Client: client, Prefix: prefix}
+}
+
+func (s *Store) key(id string) string {
+	return s.Prefix + id
+}
+
+// Get implements session.Store.
+func (s *Store) Get(ctx context.Context, id string) (map[string]any, bool, error) {
+	raw, err := s.Client.Get(ctx, s.key(id)).Bytes()
+	if err == goredis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("session/redis: get: %w", err)
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, false, fmt.Errorf("session/redis: decoding session: %w", err)
+	}
+	return values, true, nil
+}
+
+// Save implements session.Store.
+func (s *Store) Save(ctx context.Context, id string, values map[string]any, ttl time.Duration) (string, error) {
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("session/redis: encoding session: %w", err)
+	}
+	if err := s.Client.Set(ctx, s.key(id), raw, ttl).Err(); err != nil {
+		return "", fmt.Errorf("session/redis: set: %w", err)
+	}
+	return id, nil
+}
+
+// Delete implements session.Store.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	if err := s.Client.Del(ctx, s.key(id)).Err(); err != nil {
+		return fmt.Errorf("session/redis: del: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jeffotoni/quick"
+)
+
+// Config configures the session middleware returned by New.
+type Config struct {
+	// Store persists session data. MemoryStore is used when nil.
+	Store Store
+
+	// CookieName is the name of the cookie holding the session ID.
+	// Defaults to "quick_session".
+	CookieName string
+
+	// MaxAge is how long a session stays valid since its last Save.
+	// Defaults to 24 hours.
+	MaxAge time.Duration
+
+	// Path, Domain, Secure, HttpOnly and SameSite configure the session
+	// cookie. HttpOnly defaults to true and SameSite defaults to
+	// http.SameSiteLaxMode.
+	Path     string
+	Domain   string
+	Secure   bool
+	HttpOnly *bool
+	SameSite http.SameSite
+}
+
+func (cfg *Config) withDefaults() Config {
+	out := *cfg
+	if out.Store == nil {
+		out.Store = NewMemoryStore()
+	}
+	if out.CookieName == "" {
+		out.CookieName = "quick_session"
+	}
+	if out.MaxAge <= 0 {
+		out.MaxAge = 24 * time.Hour
+	}
+	if out.Path == "" {
+		out.Path = "/"
+	}
+	if out.SameSite == 0 {
+		out.SameSite = http.SameSiteLaxMode
+	}
+	if out.HttpOnly == nil {
+		t := true
+		out.HttpOnly = &t
+	}
+	return out
+}
+
+// Session is the per-request handle returned by Ctx.Session(). It is backed
+// by the Store configured in Config and must be persisted explicitly with
+// Save (or Destroy to end it).
+type Session struct {
+	mu      sync.Mutex
+	id      string
+	values  map[string]any
+	flashes []string
+	store   Store
+	cfg     Config
+	ctx     context.Context
+	w       http.ResponseWriter
+	dirty   bool
+}
+
+// Get returns the value stored under key, or nil if it is unset.
+func (s *Session) Get(key string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key]
+}
+
+// Set stores value under key. Callers must call Save to persist it.
+func (s *Session) Set(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	s.dirty = true
+}
+
+// Delete removes key from the session. Callers must call Save to persist
+// the change.
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+	s.dirty = true
+}
+
+// Flash queues a one-time message read by the next request's Flashes call.
+func (s *Session) Flash(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flashes = append(s.flashes, message)
+	s.dirty = true
+}
+
+// Flashes returns and clears all queued flash messages.
+func (s *Session) Flashes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.flashes
+	s.flashes = nil
+	s.dirty = true
+	return out
+}
+
+// Save persists the session to the Store and (re)writes the session cookie.
+func (s *Session) Save() error {
+	s.mu.Lock()
+	values := cloneValues(s.values)
+	flashes := append([]string(nil), s.flashes...)
+	id := s.id
+	s.dirty = false
+	s.mu.Unlock()
+
+	values["_flashes"] = flashes
+	cookieValue, err := s.store.Save(s.ctx, id, values, s.cfg.MaxAge)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.id = cookieValue
+	s.mu.Unlock()
+
+	s.writeCookie(cookieValue)
+	return nil
+}
+
+// Regenerate issues a new session ID, copying over the current values, and
+// destroys the old ID in the Store. Call this after a successful login to
+// prevent session fixation.
+func (s *Session) Regenerate() error {
+	s.mu.Lock()
+	oldID := s.id
+	newID, err := newSessionID()
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.id = newID
+	s.mu.Unlock()
+
+	if err := s.store.Delete(s.ctx, oldID); err != nil {
+		return err
+	}
+	return s.Save()
+}
+
+// Destroy removes the session from the Store and expires its cookie.
+func (s *Session) Destroy() error {
+	s.mu.Lock()
+	id := s.id
+	s.mu.Unlock()
+
+	if err := s.store.Delete(s.ctx, id); err != nil {
+		return err
+	}
+
+	cookie := &http.Cookie{
+		Name:     s.cfg.CookieName,
+		Value:    "",
+		Path:     s.cfg.Path,
+		Domain:   s.cfg.Domain,
+		Secure:   s.cfg.Secure,
+		HttpOnly: *s.cfg.HttpOnly,
+		SameSite: s.cfg.SameSite,
+		MaxAge:   -1,
+	}
+	http.SetCookie(s.w, cookie)
+	return nil
+}
+
+func (s *Session) writeCookie(id string) {
+	cookie := &http.Cookie{
+		Name:     s.cfg.CookieName,
+		Value:    id,
+		Path:     s.cfg.Path,
+		Domain:   s.cfg.Domain,
+		Secure:   s.cfg.Secure,
+		HttpOnly: *s.cfg.HttpOnly,
+		SameSite: s.cfg.SameSite,
+		MaxAge:   int(s.cfg.MaxAge.Seconds()),
+	}
+	http.SetCookie(s.w, cookie)
+}
+
+func cloneValues(in map[string]any) map[string]any {
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// newSessionID returns a 128-bit cryptographically random, URL-safe session
+// identifier.
+func newSessionID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("session: generating id: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// New returns standard net/http middleware, suitable for Quick.Use, that
+// attaches a *Session to every request, loading it from cfg.Store when a
+// valid session cookie is present and creating a fresh one otherwise.
+func New(cfg Config) func(http.Handler) http.Handler {
+	resolved := cfg.withDefaults()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sess := &Session{store: resolved.Store, cfg: resolved, ctx: r.Context(), w: w, values: map[string]any{}}
+
+			if cookie, err := r.Cookie(resolved.CookieName); err == nil && cookie.Value != "" {
+				if values, ok, err := resolved.Store.Get(r.Context(), cookie.Value); err == nil && ok {
+					sess.id = cookie.Value
+					// Stores that round-trip through JSON return []any.
+					switch flashes := values["_flashes"].(type) {
+					case []string:
+						sess.flashes = flashes
+					case []any:
+						for _, f := range flashes {
+							if msg, ok := f.(string); ok {
+								sess.flashes = append(sess.flashes, msg)
+							}
+						}
+					}
+					delete(values, "_flashes")
+					sess.values = values
+				}
+			}
+
+			if sess.id == "" {
+				id, err := newSessionID()
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				sess.id = id
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), quick.SessionContextKey, sess)))
+		})
+	}
+}
+
+// FromCtx returns the *Session attached to c by the session middleware, or
+// nil if the middleware was not installed.
+func FromCtx(c *quick.Ctx) *Session {
+	sess, _ := c.Request.Context().Value(quick.SessionContextKey).(*Session)
+	return sess
+}
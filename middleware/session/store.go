@@ -0,0 +1,28 @@
+// Package session provides a pluggable session subsystem for Quick. It
+// attaches a *Session to each request via c.Session(), backed by one of the
+// Store implementations in this package (or a custom one).
+package session
+
+import (
+	"context"
+	"time"
+)
+
+// Store persists session data keyed by session ID. Implementations must be
+// safe for concurrent use.
+type Store interface {
+	// Get returns the stored values for id, or ok=false when id is unknown
+	// or expired.
+	Get(ctx context.Context, id string) (values map[string]any, ok bool, err error)
+
+	// Save persists values for id with the given time-to-live and returns
+	// the cookie value to use going forward. Most stores return id
+	// unchanged; CookieStore returns the freshly signed/encrypted payload,
+	// since for it the cookie value and the stored data are the same
+	// thing, except for a session too large for a cookie, where it returns
+	// whatever its Fallback store returns.
+	Save(ctx context.Context, id string, values map[string]any, ttl time.Duration) (cookieValue string, err error)
+
+	// Delete removes id from the store.
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,141 @@
+package quick
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Route is returned by Get/Post/Put/Patch/Delete/Options so callers can
+// attach a name for reverse URL lookup via Quick.URL. Each registration
+// method must append its *Route to q.routes so Routes can enumerate it.
+type Route struct {
+	Method  string
+	Pattern string
+	Handler Handler
+	quick   *Quick
+}
+
+// handlerName returns the function name backing r.Handler (e.g.
+// "main.listUsers"), or "" if r.Handler is nil.
+func (r *Route) handlerName() string {
+	if r.Handler == nil {
+		return ""
+	}
+	fn := runtime.FuncForPC(reflect.ValueOf(r.Handler).Pointer())
+	if fn == nil {
+		return ""
+	}
+	return fn.Name()
+}
+
+// RouteInfo describes a single registered route, as returned by
+// Quick.Routes for introspection (e.g. a debug endpoint listing all
+// routes).
+type RouteInfo struct {
+	Name    string
+	Method  string
+	Pattern string
+	Handler string
+}
+
+// ErrMissingRouteParam is returned by Quick.URL when params does not
+// contain a value for one of the route pattern's named segments.
+type ErrMissingRouteParam struct {
+	Route string
+	Param string
+}
+
+func (e *ErrMissingRouteParam) Error() string {
+	return fmt.Sprintf("quick: building URL for route %q: missing param %q", e.Route, e.Param)
+}
+
+// Name registers name as the lookup key for r, so it can later be built
+// with Quick.URL. Names must be unique per Quick instance; registering the
+// same name twice overwrites the earlier route.
+func (r *Route) Name(name string) *Route {
+	if r.quick.namedRoutes == nil {
+		r.quick.namedRoutes = make(map[string]*Route)
+	}
+	r.quick.namedRoutes[name] = r
+	return r
+}
+
+// URL reconstructs the URL for the route registered under name,
+// substituting ":param"/"*wildcard" pattern segments with values from
+// params and appending query as a query string. It returns
+// *ErrMissingRouteParam if params is missing a value the pattern requires.
+func (q *Quick) URL(name string, params map[string]any, query ...map[string]string) (string, error) {
+	route, ok := q.namedRoutes[name]
+	if !ok {
+		return "", fmt.Errorf("quick: no route named %q", name)
+	}
+
+	segments := strings.Split(route.Pattern, "/")
+	for i, seg := range segments {
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			key := strings.TrimPrefix(seg, ":")
+			val, ok := params[key]
+			if !ok {
+				return "", &ErrMissingRouteParam{Route: name, Param: key}
+			}
+			segments[i] = url.PathEscape(fmt.Sprintf("%v", val))
+		case strings.HasPrefix(seg, "*"):
+			key := strings.TrimPrefix(seg, "*")
+			if key == "" {
+				key = "*"
+			}
+			val, ok := params[key]
+			if !ok {
+				return "", &ErrMissingRouteParam{Route: name, Param: key}
+			}
+			segments[i] = escapeWildcard(fmt.Sprintf("%v", val))
+		}
+	}
+
+	built := strings.Join(segments, "/")
+	if len(query) == 0 || len(query[0]) == 0 {
+		return built, nil
+	}
+
+	values := url.Values{}
+	for k, v := range query[0] {
+		values.Set(k, v)
+	}
+	return built + "?" + values.Encode(), nil
+}
+
+// escapeWildcard percent-encodes val segment by segment, preserving its
+// "/" separators, since a *wildcard capture (unlike a ":param") routinely
+// contains them, e.g. reversing "/files/*filepath" with
+// filepath="a/b.txt" must produce "/files/a/b.txt", not "/files/a%2Fb.txt".
+func escapeWildcard(val string) string {
+	parts := strings.Split(val, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
+
+// Routes returns every route registered on q, for introspection (e.g. a
+// debug endpoint or generating API documentation).
+func (q *Quick) Routes() []RouteInfo {
+	byRoute := make(map[*Route]string, len(q.namedRoutes))
+	for name, r := range q.namedRoutes {
+		byRoute[r] = name
+	}
+
+	infos := make([]RouteInfo, 0, len(q.routes))
+	for _, r := range q.routes {
+		infos = append(infos, RouteInfo{
+			Name:    byRoute[r],
+			Method:  r.Method,
+			Pattern: r.Pattern,
+			Handler: r.handlerName(),
+		})
+	}
+	return infos
+}
@@ -0,0 +1,67 @@
+package quick
+
+import "testing"
+
+func TestRouteURLEscapesParamSegment(t *testing.T) {
+	q := &Quick{}
+	route := &Route{Method: "GET", Pattern: "/users/:id", quick: q}
+	route.Name("users.show")
+
+	got, err := q.URL("users.show", map[string]any{"id": "a b/c"})
+	if err != nil {
+		t.Fatalf("URL: %v", err)
+	}
+	want := "/users/a%20b%2Fc"
+	if got != want {
+		t.Fatalf("URL = %q, want %q", got, want)
+	}
+}
+
+func TestRouteURLEscapesWildcardSegmentsButKeepsSlashes(t *testing.T) {
+	q := &Quick{}
+	route := &Route{Method: "GET", Pattern: "/files/*filepath", quick: q}
+	route.Name("files.download")
+
+	got, err := q.URL("files.download", map[string]any{"filepath": "a/b c.txt"})
+	if err != nil {
+		t.Fatalf("URL: %v", err)
+	}
+	want := "/files/a/b%20c.txt"
+	if got != want {
+		t.Fatalf("URL = %q, want %q (wildcard slashes must survive escaping)", got, want)
+	}
+}
+
+func TestRouteURLMissingParam(t *testing.T) {
+	q := &Quick{}
+	route := &Route{Method: "GET", Pattern: "/users/:id", quick: q}
+	route.Name("users.show")
+
+	_, err := q.URL("users.show", map[string]any{})
+	missing, ok := err.(*ErrMissingRouteParam)
+	if !ok {
+		t.Fatalf("URL error = %v (%T), want *ErrMissingRouteParam", err, err)
+	}
+	if missing.Param != "id" {
+		t.Fatalf("missing.Param = %q, want %q", missing.Param, "id")
+	}
+}
+
+func TestQuickRoutesReportsNamedAndUnnamedRoutes(t *testing.T) {
+	q := &Quick{}
+	named := &Route{Method: "GET", Pattern: "/users/:id", quick: q}
+	named.Name("users.show")
+	unnamed := &Route{Method: "POST", Pattern: "/users"}
+	q.routes = []*Route{named, unnamed}
+
+	infos := q.Routes()
+	if len(infos) != 2 {
+		t.Fatalf("len(Routes()) = %d, want 2", len(infos))
+	}
+	if infos[0].Name != "users.show" {
+		t.Fatalf("infos[0].Name = %q, want %q", infos[0].Name, "users.show")
+	}
+	if infos[1].Name != "" {
+		t.Fatalf("infos[1].Name = %q, want empty for an unnamed route", infos[1].Name)
+	}
+}
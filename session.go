@@ -0,0 +1,20 @@
+package quick
+
+// sessionContextKeyType is unexported so no other package can construct a
+// colliding context key; SessionContextKey is the only valid value.
+type sessionContextKeyType struct{}
+
+// SessionContextKey is the context.Context key under which
+// middleware/session stores the active session for a request. It is
+// exported so the session middleware (which this package cannot import,
+// to avoid an import cycle) and Ctx.Session can agree on where to find it.
+var SessionContextKey = sessionContextKeyType{}
+
+// Session returns the value attached to c's request context by
+// middleware/session's New middleware, or nil if that middleware is not
+// installed on this route. Its concrete type is *session.Session; callers
+// normally retrieve it as session.FromCtx(c) instead of calling this
+// directly.
+func (c *Ctx) Session() any {
+	return c.Request.Context().Value(SessionContextKey)
+}
@@ -0,0 +1,86 @@
+package quick
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SSEStream writes a Server-Sent Events response. Obtain one via Ctx.SSE.
+type SSEStream struct {
+	c       *Ctx
+	flusher http.Flusher
+}
+
+// SSE prepares c's response for Server-Sent Events: it sets the
+// text/event-stream content type, disables intermediary buffering, and
+// returns a stream that writes individual events via Send/SendJSON.
+func (c *Ctx) SSE() *SSEStream {
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	flusher, _ := c.Response.(http.Flusher)
+	return &SSEStream{c: c, flusher: flusher}
+}
+
+// Send writes a single SSE event with the given event name and data. An
+// empty event name omits the "event:" field, producing an unnamed message.
+func (s *SSEStream) Send(event, data string) error {
+	var b strings.Builder
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	if _, err := s.c.Response.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	s.Flush()
+	return nil
+}
+
+// SendJSON marshals v and sends it as the data of an SSE event.
+func (s *SSEStream) SendJSON(event string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.Send(event, string(body))
+}
+
+// ID writes an SSE "id:" field, letting clients resume with
+// Last-Event-ID on reconnect.
+func (s *SSEStream) ID(id string) error {
+	_, err := fmt.Fprintf(s.c.Response, "id: %s\n\n", id)
+	s.Flush()
+	return err
+}
+
+// Retry tells the client how long to wait, in milliseconds, before
+// attempting to reconnect after the connection drops.
+func (s *SSEStream) Retry(ms int) error {
+	_, err := fmt.Fprintf(s.c.Response, "retry: %d\n\n", ms)
+	s.Flush()
+	return err
+}
+
+// Flush forces any buffered bytes to the client immediately. It is called
+// automatically by Send/SendJSON/ID/Retry, so callers rarely need it
+// directly.
+func (s *SSEStream) Flush() {
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+}
+
+// Done returns a channel closed when the client disconnects, so a handler
+// streaming events can stop writing instead of blocking forever.
+func (s *SSEStream) Done() <-chan struct{} {
+	return s.c.Request.Context().Done()
+}
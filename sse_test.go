@@ -0,0 +1,82 @@
+package quick
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSSECtx(t *testing.T) (*Ctx, *httptest.ResponseRecorder) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := httptest.NewRecorder()
+	return &Ctx{Request: req, Response: rec}, rec
+}
+
+func TestSSESetsStreamingHeaders(t *testing.T) {
+	c, rec := newSSECtx(t)
+	c.SSE()
+
+	for header, want := range map[string]string{
+		"Content-Type":      "text/event-stream",
+		"Cache-Control":     "no-cache",
+		"Connection":        "keep-alive",
+		"X-Accel-Buffering": "no",
+	} {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("header %s = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestSSESendWritesEventAndData(t *testing.T) {
+	c, rec := newSSECtx(t)
+	s := c.SSE()
+
+	if err := s.Send("ping", "hello\nworld"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "event: ping\n") {
+		t.Fatalf("body = %q, want an \"event: ping\" line", body)
+	}
+	if !strings.Contains(body, "data: hello\n") || !strings.Contains(body, "data: world\n") {
+		t.Fatalf("body = %q, want each data line prefixed with \"data: \"", body)
+	}
+}
+
+func TestSSESendJSONMarshalsData(t *testing.T) {
+	c, rec := newSSECtx(t)
+	s := c.SSE()
+
+	if err := s.SendJSON("user", map[string]int{"id": 1}); err != nil {
+		t.Fatalf("SendJSON: %v", err)
+	}
+	if !strings.Contains(rec.Body.String(), `data: {"id":1}`) {
+		t.Fatalf("body = %q, want the marshaled JSON on the data line", rec.Body.String())
+	}
+}
+
+func TestSSEDoneClosesWithRequestContext(t *testing.T) {
+	c, _ := newSSECtx(t)
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	c.Request = c.Request.WithContext(ctx)
+	s := c.SSE()
+
+	select {
+	case <-s.Done():
+		t.Fatal("Done() channel closed before the request context was canceled")
+	default:
+	}
+
+	cancel()
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done() channel not closed after the request context was canceled")
+	}
+}
@@ -0,0 +1,251 @@
+package quick
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultBrowseTemplate is used to render a directory listing whenever a
+// StaticConfig does not provide its own Template. It is intentionally
+// dependency-free so Quick keeps working without any asset pipeline.
+var defaultBrowseTemplate = template.Must(template.New("quick-static-browse").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Path}}</title></head>
+<body>
+<h1>Index of {{.Path}}</h1>
+<ul>
+{{if .CanGoUp}}<li><a href="../">../</a></li>{{end}}
+{{range .Items}}<li><a href="{{.URL}}">{{.Name}}</a>{{if not .IsDir}} - {{.Size}} bytes{{end}}</li>
+{{end}}
+</ul>
+<p>{{.NumDirs}} directories, {{.NumFiles}} files</p>
+</body>
+</html>
+`))
+
+// FileInfo describes a single entry rendered in a directory listing.
+type FileInfo struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	IsDir   bool      `json:"isDir"`
+	ModTime time.Time `json:"modTime"`
+	URL     string    `json:"url"`
+}
+
+// browseContext is the data handed to the directory-listing template (or
+// marshaled as JSON when the caller prefers a machine-readable response).
+type browseContext struct {
+	Name     string     `json:"name"`
+	Path     string     `json:"path"`
+	CanGoUp  bool       `json:"canGoUp"`
+	Items    []FileInfo `json:"items"`
+	NumDirs  int        `json:"numDirs"`
+	NumFiles int        `json:"numFiles"`
+	Sort     string     `json:"sort"`
+	Order    string     `json:"order"`
+}
+
+// StaticConfig configures how Quick.Static serves a mounted directory,
+// including the optional directory-listing (a.k.a. "autoindex") mode.
+type StaticConfig struct {
+	// Browse enables directory-listing when a request maps to a directory
+	// that has no index.html. It is disabled by default so existing
+	// Quick.Static calls keep their current behavior.
+	Browse bool
+
+	// IgnoreIndexes, when true, skips the index.html lookup entirely, so
+	// directory requests always get the directory listing when Browse is
+	// set (and a 404 when it is not).
+	IgnoreIndexes bool
+
+	// Template renders the directory listing. defaultBrowseTemplate is used
+	// when nil.
+	Template *template.Template
+
+	// Ignore is a list of glob patterns (matched with path.Match against the
+	// entry name) that are hidden from the listing for this mount.
+	Ignore []string
+}
+
+// shouldIgnore reports whether name matches one of cfg's Ignore patterns.
+func (cfg StaticConfig) shouldIgnore(name string) bool {
+	for _, pattern := range cfg.Ignore {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sortFileInfos orders items in place according to the sort/order query
+// params ("name", "size" or "modtime"; "asc" or "desc").
+func sortFileInfos(items []FileInfo, sortBy, order string) {
+	less := func(i, j int) bool {
+		switch sortBy {
+		case "size":
+			return items[i].Size < items[j].Size
+		case "modtime":
+			return items[i].ModTime.Before(items[j].ModTime)
+		default:
+			return strings.ToLower(items[i].Name) < strings.ToLower(items[j].Name)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		// directories are always listed before files, regardless of sort key
+		if items[i].IsDir != items[j].IsDir {
+			return items[i].IsDir
+		}
+		if order == "desc" {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+// humanSize renders n bytes as a short human-readable string (e.g. "12.3 KB").
+func humanSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	units := []string{"KB", "MB", "GB", "TB", "PB"}
+	return strconv.FormatFloat(float64(n)/float64(div), 'f', 1, 64) + " " + units[exp]
+}
+
+// FileServer returns a Handler serving files under root, honoring cfg's
+// Browse/IgnoreIndexes/Ignore/Template settings for directory requests.
+// Mount it on a wildcard route the same way Quick.Static mounts its own
+// file serving, e.g.:
+//
+//	q.Get("/files/*filepath", quick.FileServer("./public", quick.StaticConfig{Browse: true}))
+//
+// The "*filepath" segment (read from c.Params["filepath"]) selects which
+// file or directory under root is served.
+func FileServer(root string, cfg StaticConfig) Handler {
+	return func(c *Ctx) error {
+		// path.Clean anchors the request path and collapses any ".."
+		// segments before it ever reaches the filesystem, so a request
+		// like "/files/../../etc/passwd" can't escape root.
+		rel := path.Clean("/" + c.Params["filepath"])
+		fsPath := filepath.Join(root, filepath.FromSlash(rel))
+
+		info, err := os.Stat(fsPath)
+		if err != nil {
+			http.NotFound(c.Response, c.Request)
+			return nil
+		}
+
+		if !info.IsDir() {
+			http.ServeFile(c.Response, c.Request, fsPath)
+			return nil
+		}
+
+		if !cfg.IgnoreIndexes {
+			indexPath := filepath.Join(fsPath, "index.html")
+			if indexInfo, err := os.Stat(indexPath); err == nil && !indexInfo.IsDir() {
+				http.ServeFile(c.Response, c.Request, indexPath)
+				return nil
+			}
+		}
+
+		if !cfg.Browse {
+			http.NotFound(c.Response, c.Request)
+			return nil
+		}
+
+		entries, err := readDirEntries(fsPath, cfg)
+		if err != nil {
+			return err
+		}
+		return renderDirectoryListing(c, cfg, rel, entries)
+	}
+}
+
+// readDirEntries lists dir's immediate children as []FileInfo, skipping
+// any name matched by cfg.Ignore.
+func readDirEntries(dir string, cfg StaticConfig) ([]FileInfo, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]FileInfo, 0, len(dirEntries))
+	for _, de := range dirEntries {
+		if cfg.shouldIgnore(de.Name()) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+
+		url := de.Name()
+		if de.IsDir() {
+			url += "/"
+		}
+		items = append(items, FileInfo{
+			Name:    de.Name(),
+			Size:    info.Size(),
+			IsDir:   de.IsDir(),
+			ModTime: info.ModTime(),
+			URL:     url,
+		})
+	}
+	return items, nil
+}
+
+// renderDirectoryListing writes a directory listing for dirPath (the URL
+// path requested) built from entries. It answers with JSON when the client
+// sent Accept: application/json, otherwise it renders cfg.Template (or
+// defaultBrowseTemplate).
+func renderDirectoryListing(c *Ctx, cfg StaticConfig, dirPath string, entries []FileInfo) error {
+	sortBy := c.Query["sort"]
+	order := c.Query["order"]
+	sortFileInfos(entries, sortBy, order)
+
+	numDirs, numFiles := 0, 0
+	for _, e := range entries {
+		if e.IsDir {
+			numDirs++
+		} else {
+			numFiles++
+		}
+	}
+
+	ctx := browseContext{
+		Name:     path.Base(strings.TrimSuffix(dirPath, "/")),
+		Path:     dirPath,
+		CanGoUp:  dirPath != "/" && dirPath != "",
+		Items:    entries,
+		NumDirs:  numDirs,
+		NumFiles: numFiles,
+		Sort:     sortBy,
+		Order:    order,
+	}
+
+	if strings.Contains(c.Request.Header.Get("Accept"), "application/json") {
+		c.Set("Content-Type", "application/json")
+		return json.NewEncoder(c.Response).Encode(ctx)
+	}
+
+	tpl := cfg.Template
+	if tpl == nil {
+		tpl = defaultBrowseTemplate
+	}
+	c.Set("Content-Type", "text/html; charset=utf-8")
+	c.Response.WriteHeader(http.StatusOK)
+	return tpl.Execute(c.Response, ctx)
+}
@@ -0,0 +1,132 @@
+package quick
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newFileServerCtx(t *testing.T, filepathParam string) (*Ctx, *httptest.ResponseRecorder) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/files/"+filepathParam, nil)
+	rec := httptest.NewRecorder()
+	return &Ctx{
+		Request:  req,
+		Response: rec,
+		Params:   map[string]string{"filepath": filepathParam},
+		Query:    map[string]string{},
+	}, rec
+}
+
+func TestFileServerServesFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := FileServer(dir, StaticConfig{})
+	c, rec := newFileServerCtx(t, "hello.txt")
+	if err := h(c); err != nil {
+		t.Fatalf("FileServer: %v", err)
+	}
+	if rec.Code != http.StatusOK || rec.Body.String() != "hi" {
+		t.Fatalf("got status=%d body=%q, want 200 %q", rec.Code, rec.Body.String(), "hi")
+	}
+}
+
+func TestFileServerServesIndexForDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<h1>hi</h1>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := FileServer(dir, StaticConfig{})
+	c, rec := newFileServerCtx(t, "")
+	if err := h(c); err != nil {
+		t.Fatalf("FileServer: %v", err)
+	}
+	if rec.Code != http.StatusOK || rec.Body.String() != "<h1>hi</h1>" {
+		t.Fatalf("got status=%d body=%q, want the index.html contents", rec.Code, rec.Body.String())
+	}
+}
+
+func TestFileServerBrowsesDirectoryWithoutIndex(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	h := FileServer(dir, StaticConfig{Browse: true})
+	c, rec := newFileServerCtx(t, "")
+	c.Request.Header.Set("Accept", "application/json")
+	if err := h(c); err != nil {
+		t.Fatalf("FileServer: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `"name":"a.txt"`) || !strings.Contains(body, `"name":"sub"`) {
+		t.Fatalf("listing JSON = %s, want entries for a.txt and sub", body)
+	}
+}
+
+func TestFileServerIgnoresMatchingEntries(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"keep.txt", ".hidden"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := FileServer(dir, StaticConfig{Browse: true, Ignore: []string{".*"}})
+	c, rec := newFileServerCtx(t, "")
+	c.Request.Header.Set("Accept", "application/json")
+	if err := h(c); err != nil {
+		t.Fatalf("FileServer: %v", err)
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, ".hidden") {
+		t.Fatalf("listing JSON = %s, want .hidden excluded by Ignore", body)
+	}
+	if !strings.Contains(body, "keep.txt") {
+		t.Fatalf("listing JSON = %s, want keep.txt present", body)
+	}
+}
+
+func TestFileServerWithoutBrowseReturns404ForDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	h := FileServer(dir, StaticConfig{})
+	c, rec := newFileServerCtx(t, "")
+	if err := h(c); err != nil {
+		t.Fatalf("FileServer: %v", err)
+	}
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404 when Browse is disabled and there is no index.html", rec.Code)
+	}
+}
+
+func TestFileServerRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(filepath.Dir(dir), "secret.txt")
+	if err := os.WriteFile(secret, []byte("top secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(secret)
+
+	h := FileServer(dir, StaticConfig{})
+	c, rec := newFileServerCtx(t, "../secret.txt")
+	if err := h(c); err != nil {
+		t.Fatalf("FileServer: %v", err)
+	}
+	if strings.Contains(rec.Body.String(), "top secret") {
+		t.Fatal("FileServer served a file outside root via path traversal")
+	}
+}